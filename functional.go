@@ -0,0 +1,89 @@
+/*
+Open Source Initiative OSI - The MIT License (MIT):Licensing
+
+The MIT License (MIT)
+Copyright (c) 2013 Ralph Caraveo (deckarep@gmail.com)
+
+Permission is hereby granted, free of charge, to any person obtaining a copy of
+this software and associated documentation files (the "Software"), to deal in
+the Software without restriction, including without limitation the rights to
+use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies
+of the Software, and to permit persons to whom the Software is furnished to do
+so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package mapset
+
+// Filter returns a new set containing only the elements of set for which
+// pred returns true.
+func (set *threadUnsafeSet) Filter(pred func(interface{}) bool) Set {
+	result := newThreadUnsafeSet()
+	for elem := range *set {
+		if pred(elem) {
+			result.Add(elem)
+		}
+	}
+	return &result
+}
+
+// Map returns a new set containing the result of applying fn to every
+// element of set. Since fn may map distinct elements to the same value,
+// the result can have a smaller cardinality than set.
+func (set *threadUnsafeSet) Map(fn func(interface{}) interface{}) Set {
+	result := newThreadUnsafeSet()
+	for elem := range *set {
+		result.Add(fn(elem))
+	}
+	return &result
+}
+
+// Reduce folds fn over set's elements, starting from init, and returns the
+// final accumulated value. Set iteration order is unspecified, so fn
+// should be commutative and associative.
+func (set *threadUnsafeSet) Reduce(init interface{}, fn func(acc, elem interface{}) interface{}) interface{} {
+	acc := init
+	for elem := range *set {
+		acc = fn(acc, elem)
+	}
+	return acc
+}
+
+// Filter returns a new set containing only the elements of set for which
+// pred returns true. pred is evaluated while set's read lock is held.
+func (set *threadSafeSet) Filter(pred func(interface{}) bool) Set {
+	set.l.RLock()
+	defer set.l.RUnlock()
+
+	unsafeResult := set.S.Filter(pred).(*threadUnsafeSet)
+	return &threadSafeSet{S: *unsafeResult}
+}
+
+// Map returns a new set containing the result of applying fn to every
+// element of set. fn is evaluated while set's read lock is held.
+func (set *threadSafeSet) Map(fn func(interface{}) interface{}) Set {
+	set.l.RLock()
+	defer set.l.RUnlock()
+
+	unsafeResult := set.S.Map(fn).(*threadUnsafeSet)
+	return &threadSafeSet{S: *unsafeResult}
+}
+
+// Reduce folds fn over set's elements, starting from init, and returns the
+// final accumulated value. fn is evaluated while set's read lock is held.
+func (set *threadSafeSet) Reduce(init interface{}, fn func(acc, elem interface{}) interface{}) interface{} {
+	set.l.RLock()
+	defer set.l.RUnlock()
+
+	return set.S.Reduce(init, fn)
+}
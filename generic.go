@@ -0,0 +1,609 @@
+/*
+Open Source Initiative OSI - The MIT License (MIT):Licensing
+
+The MIT License (MIT)
+Copyright (c) 2013 Ralph Caraveo (deckarep@gmail.com)
+
+Permission is hereby granted, free of charge, to any person obtaining a copy of
+this software and associated documentation files (the "Software"), to deal in
+the Software without restriction, including without limitation the rights to
+use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies
+of the Software, and to permit persons to whom the Software is furnished to do
+so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package mapset
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// TypedSet[T] is the generic counterpart of Set, typed over T instead of
+// interface{}.
+type TypedSet[T comparable] interface {
+	Add(i T) bool
+	Contains(i ...T) bool
+	IsSubset(other TypedSet[T]) bool
+	IsProperSubset(other TypedSet[T]) bool
+	IsSuperset(other TypedSet[T]) bool
+	IsProperSuperset(other TypedSet[T]) bool
+	Union(other TypedSet[T]) TypedSet[T]
+	Intersect(other TypedSet[T]) TypedSet[T]
+	Difference(other TypedSet[T]) TypedSet[T]
+	SymmetricDifference(other TypedSet[T]) TypedSet[T]
+	Clear()
+	Remove(i T)
+	Cardinality() int
+	Each(cb func(T) bool)
+	Iter() <-chan T
+	Iterator() *TypedIterator[T]
+	Equal(other TypedSet[T]) bool
+	Clone() TypedSet[T]
+	String() string
+	Pop() (T, bool)
+	ToSlice() []T
+	MarshalJSON() ([]byte, error)
+	UnmarshalJSON(b []byte) error
+}
+
+// Pair is the element type produced by CartesianProduct: one element drawn
+// from the receiver and one from the argument set.
+type Pair[T any, U any] struct {
+	First  T
+	Second U
+}
+
+func (p Pair[T, U]) String() string {
+	return fmt.Sprintf("(%v, %v)", p.First, p.Second)
+}
+
+// NewTypedSet creates and returns a new thread-safe TypedSet[T], seeded with
+// the given elements if any are passed.
+func NewTypedSet[T comparable](s ...T) TypedSet[T] {
+	set := newTypedThreadSafeSet[T]()
+	for _, item := range s {
+		set.Add(item)
+	}
+	return &set
+}
+
+// NewTypedThreadUnsafeSet creates and returns a new unsafe TypedSet[T],
+// without the overhead of synchronization.
+func NewTypedThreadUnsafeSet[T comparable](s ...T) TypedSet[T] {
+	set := newTypedThreadUnsafeSet[T]()
+	for _, item := range s {
+		set.Add(item)
+	}
+	return &set
+}
+
+type typedThreadUnsafeSet[T comparable] map[T]struct{}
+
+func newTypedThreadUnsafeSet[T comparable]() typedThreadUnsafeSet[T] {
+	return make(typedThreadUnsafeSet[T])
+}
+
+func (set *typedThreadUnsafeSet[T]) Add(i T) bool {
+	_, found := (*set)[i]
+	if found {
+		return false
+	}
+	(*set)[i] = struct{}{}
+	return true
+}
+
+func (set *typedThreadUnsafeSet[T]) Contains(i ...T) bool {
+	for _, val := range i {
+		if _, ok := (*set)[val]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+func (set *typedThreadUnsafeSet[T]) IsSubset(other TypedSet[T]) bool {
+	o := other.(*typedThreadUnsafeSet[T])
+	if set.Cardinality() > o.Cardinality() {
+		return false
+	}
+	for elem := range *set {
+		if !o.Contains(elem) {
+			return false
+		}
+	}
+	return true
+}
+
+func (set *typedThreadUnsafeSet[T]) IsProperSubset(other TypedSet[T]) bool {
+	return set.IsSubset(other) && set.Cardinality() < other.Cardinality()
+}
+
+func (set *typedThreadUnsafeSet[T]) IsSuperset(other TypedSet[T]) bool {
+	return other.IsSubset(set)
+}
+
+func (set *typedThreadUnsafeSet[T]) IsProperSuperset(other TypedSet[T]) bool {
+	return other.IsProperSubset(set)
+}
+
+func (set *typedThreadUnsafeSet[T]) Union(other TypedSet[T]) TypedSet[T] {
+	o := other.(*typedThreadUnsafeSet[T])
+
+	unionedSet := newTypedThreadUnsafeSet[T]()
+	for elem := range *set {
+		unionedSet.Add(elem)
+	}
+	for elem := range *o {
+		unionedSet.Add(elem)
+	}
+	return &unionedSet
+}
+
+func (set *typedThreadUnsafeSet[T]) Intersect(other TypedSet[T]) TypedSet[T] {
+	o := other.(*typedThreadUnsafeSet[T])
+
+	intersection := newTypedThreadUnsafeSet[T]()
+	smaller, larger := set, o
+	if o.Cardinality() < set.Cardinality() {
+		smaller, larger = o, set
+	}
+	for elem := range *smaller {
+		if larger.Contains(elem) {
+			intersection.Add(elem)
+		}
+	}
+	return &intersection
+}
+
+func (set *typedThreadUnsafeSet[T]) Difference(other TypedSet[T]) TypedSet[T] {
+	o := other.(*typedThreadUnsafeSet[T])
+
+	diff := newTypedThreadUnsafeSet[T]()
+	for elem := range *set {
+		if !o.Contains(elem) {
+			diff.Add(elem)
+		}
+	}
+	return &diff
+}
+
+func (set *typedThreadUnsafeSet[T]) SymmetricDifference(other TypedSet[T]) TypedSet[T] {
+	o := other.(*typedThreadUnsafeSet[T])
+
+	aDiff := set.Difference(o).(*typedThreadUnsafeSet[T])
+	bDiff := o.Difference(set).(*typedThreadUnsafeSet[T])
+	return aDiff.Union(bDiff)
+}
+
+func (set *typedThreadUnsafeSet[T]) Clear() {
+	*set = newTypedThreadUnsafeSet[T]()
+}
+
+func (set *typedThreadUnsafeSet[T]) Remove(i T) {
+	delete(*set, i)
+}
+
+func (set *typedThreadUnsafeSet[T]) Cardinality() int {
+	return len(*set)
+}
+
+func (set *typedThreadUnsafeSet[T]) Each(cb func(T) bool) {
+	for elem := range *set {
+		if cb(elem) {
+			break
+		}
+	}
+}
+
+func (set *typedThreadUnsafeSet[T]) Iter() <-chan T {
+	ch := make(chan T)
+	go func() {
+		for elem := range *set {
+			ch <- elem
+		}
+		close(ch)
+	}()
+	return ch
+}
+
+func (set *typedThreadUnsafeSet[T]) Iterator() *TypedIterator[T] {
+	iterator, ch, stopCh := newTypedIterator[T]()
+
+	go func() {
+	L:
+		for elem := range *set {
+			select {
+			case <-stopCh:
+				break L
+			case ch <- elem:
+			}
+		}
+		close(ch)
+	}()
+
+	return iterator
+}
+
+func (set *typedThreadUnsafeSet[T]) Equal(other TypedSet[T]) bool {
+	o := other.(*typedThreadUnsafeSet[T])
+
+	if set.Cardinality() != o.Cardinality() {
+		return false
+	}
+	for elem := range *set {
+		if !o.Contains(elem) {
+			return false
+		}
+	}
+	return true
+}
+
+func (set *typedThreadUnsafeSet[T]) Clone() TypedSet[T] {
+	clone := newTypedThreadUnsafeSet[T]()
+	for elem := range *set {
+		clone.Add(elem)
+	}
+	return &clone
+}
+
+func (set *typedThreadUnsafeSet[T]) String() string {
+	items := make([]string, 0, len(*set))
+	for elem := range *set {
+		items = append(items, fmt.Sprintf("%v", elem))
+	}
+	return fmt.Sprintf("Set{%s}", strings.Join(items, ", "))
+}
+
+func (set *typedThreadUnsafeSet[T]) Pop() (T, bool) {
+	for elem := range *set {
+		delete(*set, elem)
+		return elem, true
+	}
+	var zero T
+	return zero, false
+}
+
+func (set *typedThreadUnsafeSet[T]) ToSlice() []T {
+	keys := make([]T, 0, set.Cardinality())
+	for elem := range *set {
+		keys = append(keys, elem)
+	}
+	return keys
+}
+
+func (set *typedThreadUnsafeSet[T]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(set.ToSlice())
+}
+
+func (set *typedThreadUnsafeSet[T]) UnmarshalJSON(b []byte) error {
+	var items []T
+	if err := json.Unmarshal(b, &items); err != nil {
+		return err
+	}
+	for _, item := range items {
+		set.Add(item)
+	}
+	return nil
+}
+
+// PowerSet returns the set of all subsets of set, including the empty set
+// and set itself. It is a package-level function rather than a TypedSet[T]
+// method because Go does not allow a generic method to return an
+// instantiation of its own receiver type (TypedSet[T] recursively
+// instantiated as TypedSet[TypedSet[T]] is an instantiation cycle).
+func PowerSet[T comparable](set TypedSet[T]) TypedSet[TypedSet[T]] {
+	powSet := NewTypedSet[TypedSet[T]]()
+	powSet.Add(NewTypedSet[T]())
+
+	set.Each(func(es T) bool {
+		u := NewTypedSet[TypedSet[T]]()
+		powSet.Each(func(sub TypedSet[T]) bool {
+			p := NewTypedSet[T]()
+			sub.Each(func(k T) bool {
+				p.Add(k)
+				return false
+			})
+			p.Add(es)
+			u.Add(p)
+			return false
+		})
+		u.Each(func(elem TypedSet[T]) bool {
+			powSet.Add(elem)
+			return false
+		})
+		return false
+	})
+
+	return powSet
+}
+
+// CartesianProduct returns the cartesian product of set and other as a
+// TypedSet[Pair[T, U]]: every (a, b) with a in set and b in other.
+func CartesianProduct[T comparable, U comparable](set TypedSet[T], other TypedSet[U]) TypedSet[Pair[T, U]] {
+	product := NewTypedThreadUnsafeSet[Pair[T, U]]()
+	set.Each(func(a T) bool {
+		other.Each(func(b U) bool {
+			product.Add(Pair[T, U]{First: a, Second: b})
+			return false
+		})
+		return false
+	})
+	return product
+}
+
+type typedThreadSafeSet[T comparable] struct {
+	S typedThreadUnsafeSet[T]
+	l sync.RWMutex
+}
+
+func newTypedThreadSafeSet[T comparable]() typedThreadSafeSet[T] {
+	return typedThreadSafeSet[T]{S: newTypedThreadUnsafeSet[T]()}
+}
+
+func (set *typedThreadSafeSet[T]) Add(i T) bool {
+	set.l.Lock()
+	ret := set.S.Add(i)
+	set.l.Unlock()
+	return ret
+}
+
+func (set *typedThreadSafeSet[T]) Contains(i ...T) bool {
+	set.l.RLock()
+	ret := set.S.Contains(i...)
+	set.l.RUnlock()
+	return ret
+}
+
+// IsSubset, IsProperSubset, Union, Intersect, Difference, SymmetricDifference
+// and Equal below operate on other through the TypedSet[T] interface rather
+// than asserting it to *typedThreadSafeSet[T], so a typedThreadSafeSet[T]
+// can be compared against or combined with a typedThreadUnsafeSet[T]
+// without panicking.
+
+func (set *typedThreadSafeSet[T]) IsSubset(other TypedSet[T]) bool {
+	set.l.RLock()
+	defer set.l.RUnlock()
+
+	if len(set.S) > other.Cardinality() {
+		return false
+	}
+	for elem := range set.S {
+		if !other.Contains(elem) {
+			return false
+		}
+	}
+	return true
+}
+
+func (set *typedThreadSafeSet[T]) IsProperSubset(other TypedSet[T]) bool {
+	return set.IsSubset(other) && set.Cardinality() < other.Cardinality()
+}
+
+func (set *typedThreadSafeSet[T]) IsSuperset(other TypedSet[T]) bool {
+	return other.IsSubset(set)
+}
+
+func (set *typedThreadSafeSet[T]) IsProperSuperset(other TypedSet[T]) bool {
+	return other.IsProperSubset(set)
+}
+
+func (set *typedThreadSafeSet[T]) Union(other TypedSet[T]) TypedSet[T] {
+	set.l.RLock()
+	result := newTypedThreadUnsafeSet[T]()
+	for elem := range set.S {
+		result.Add(elem)
+	}
+	set.l.RUnlock()
+
+	for elem := range other.Iter() {
+		result.Add(elem)
+	}
+	return &typedThreadSafeSet[T]{S: result}
+}
+
+func (set *typedThreadSafeSet[T]) Intersect(other TypedSet[T]) TypedSet[T] {
+	set.l.RLock()
+	defer set.l.RUnlock()
+
+	result := newTypedThreadUnsafeSet[T]()
+	for elem := range set.S {
+		if other.Contains(elem) {
+			result.Add(elem)
+		}
+	}
+	return &typedThreadSafeSet[T]{S: result}
+}
+
+func (set *typedThreadSafeSet[T]) Difference(other TypedSet[T]) TypedSet[T] {
+	set.l.RLock()
+	defer set.l.RUnlock()
+
+	result := newTypedThreadUnsafeSet[T]()
+	for elem := range set.S {
+		if !other.Contains(elem) {
+			result.Add(elem)
+		}
+	}
+	return &typedThreadSafeSet[T]{S: result}
+}
+
+func (set *typedThreadSafeSet[T]) SymmetricDifference(other TypedSet[T]) TypedSet[T] {
+	set.l.RLock()
+	result := newTypedThreadUnsafeSet[T]()
+	for elem := range set.S {
+		if !other.Contains(elem) {
+			result.Add(elem)
+		}
+	}
+	set.l.RUnlock()
+
+	for elem := range other.Iter() {
+		if !set.Contains(elem) {
+			result.Add(elem)
+		}
+	}
+	return &typedThreadSafeSet[T]{S: result}
+}
+
+func (set *typedThreadSafeSet[T]) Clear() {
+	set.l.Lock()
+	set.S = newTypedThreadUnsafeSet[T]()
+	set.l.Unlock()
+}
+
+func (set *typedThreadSafeSet[T]) Remove(i T) {
+	set.l.Lock()
+	delete(set.S, i)
+	set.l.Unlock()
+}
+
+func (set *typedThreadSafeSet[T]) Cardinality() int {
+	set.l.RLock()
+	defer set.l.RUnlock()
+	return len(set.S)
+}
+
+func (set *typedThreadSafeSet[T]) Each(cb func(T) bool) {
+	set.l.RLock()
+	for elem := range set.S {
+		if cb(elem) {
+			break
+		}
+	}
+	set.l.RUnlock()
+}
+
+func (set *typedThreadSafeSet[T]) Iter() <-chan T {
+	ch := make(chan T)
+	go func() {
+		set.l.RLock()
+
+		for elem := range set.S {
+			ch <- elem
+		}
+		close(ch)
+		set.l.RUnlock()
+	}()
+
+	return ch
+}
+
+func (set *typedThreadSafeSet[T]) Iterator() *TypedIterator[T] {
+	iterator, ch, stopCh := newTypedIterator[T]()
+
+	go func() {
+		set.l.RLock()
+	L:
+		for elem := range set.S {
+			select {
+			case <-stopCh:
+				break L
+			case ch <- elem:
+			}
+		}
+		close(ch)
+		set.l.RUnlock()
+	}()
+
+	return iterator
+}
+
+func (set *typedThreadSafeSet[T]) Equal(other TypedSet[T]) bool {
+	set.l.RLock()
+	defer set.l.RUnlock()
+
+	if len(set.S) != other.Cardinality() {
+		return false
+	}
+	for elem := range set.S {
+		if !other.Contains(elem) {
+			return false
+		}
+	}
+	return true
+}
+
+func (set *typedThreadSafeSet[T]) Clone() TypedSet[T] {
+	set.l.RLock()
+
+	unsafeClone := set.S.Clone().(*typedThreadUnsafeSet[T])
+	ret := &typedThreadSafeSet[T]{S: *unsafeClone}
+	set.l.RUnlock()
+	return ret
+}
+
+func (set *typedThreadSafeSet[T]) String() string {
+	set.l.RLock()
+	ret := set.S.String()
+	set.l.RUnlock()
+	return ret
+}
+
+func (set *typedThreadSafeSet[T]) Pop() (T, bool) {
+	set.l.Lock()
+	defer set.l.Unlock()
+	return set.S.Pop()
+}
+
+func (set *typedThreadSafeSet[T]) ToSlice() []T {
+	keys := make([]T, 0, set.Cardinality())
+	set.l.RLock()
+	for elem := range set.S {
+		keys = append(keys, elem)
+	}
+	set.l.RUnlock()
+	return keys
+}
+
+func (set *typedThreadSafeSet[T]) MarshalJSON() ([]byte, error) {
+	set.l.RLock()
+	b, err := set.S.MarshalJSON()
+	set.l.RUnlock()
+
+	return b, err
+}
+
+func (set *typedThreadSafeSet[T]) UnmarshalJSON(p []byte) error {
+	set.l.RLock()
+	err := set.S.UnmarshalJSON(p)
+	set.l.RUnlock()
+
+	return err
+}
+
+// TypedIterator[T] mirrors Iterator but yields values of type T instead of
+// interface{}.
+type TypedIterator[T any] struct {
+	C    <-chan T
+	stop chan struct{}
+}
+
+// Stop terminates the Iterator, no further values will be sent.
+func (i *TypedIterator[T]) Stop() {
+	close(i.stop)
+	for range i.C {
+	}
+}
+
+func newTypedIterator[T any]() (*TypedIterator[T], chan<- T, <-chan struct{}) {
+	itr := &TypedIterator[T]{
+		stop: make(chan struct{}),
+	}
+	ch := make(chan T)
+	itr.C = ch
+	return itr, ch, itr.stop
+}
@@ -0,0 +1,60 @@
+/*
+Open Source Initiative OSI - The MIT License (MIT):Licensing
+
+The MIT License (MIT)
+Copyright (c) 2013 Ralph Caraveo (deckarep@gmail.com)
+
+Permission is hereby granted, free of charge, to any person obtaining a copy of
+this software and associated documentation files (the "Software"), to deal in
+the Software without restriction, including without limitation the rights to
+use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies
+of the Software, and to permit persons to whom the Software is furnished to do
+so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package mapset
+
+import "testing"
+
+// TestTypedThreadSafeSetMixedBacking guards against typedThreadSafeSet[T]'s
+// binary operations panicking when other is backed by a different TypedSet[T]
+// implementation (here, typedThreadUnsafeSet[T]) instead of asserting other
+// to *typedThreadSafeSet[T].
+func TestTypedThreadSafeSetMixedBacking(t *testing.T) {
+	safe := NewTypedSet(1, 2, 3)
+	unsafeOther := NewTypedThreadUnsafeSet(2, 3, 4)
+
+	if safe.IsSubset(unsafeOther) {
+		t.Errorf("IsSubset: {1,2,3}.IsSubset({2,3,4}) = true, want false")
+	}
+	if safe.IsProperSubset(unsafeOther) {
+		t.Errorf("IsProperSubset: {1,2,3}.IsProperSubset({2,3,4}) = true, want false")
+	}
+	if safe.Equal(unsafeOther) {
+		t.Errorf("Equal: {1,2,3}.Equal({2,3,4}) = true, want false")
+	}
+
+	if union := safe.Union(unsafeOther); union.Cardinality() != 4 {
+		t.Errorf("Union cardinality = %d, want 4", union.Cardinality())
+	}
+	if inter := safe.Intersect(unsafeOther); inter.Cardinality() != 2 {
+		t.Errorf("Intersect cardinality = %d, want 2", inter.Cardinality())
+	}
+	if diff := safe.Difference(unsafeOther); diff.Cardinality() != 1 || !diff.Contains(1) {
+		t.Errorf("Difference = %v, want {1}", diff)
+	}
+	if symDiff := safe.SymmetricDifference(unsafeOther); symDiff.Cardinality() != 2 || !symDiff.Contains(1) || !symDiff.Contains(4) {
+		t.Errorf("SymmetricDifference = %v, want {1, 4}", symDiff)
+	}
+}
@@ -0,0 +1,133 @@
+/*
+Open Source Initiative OSI - The MIT License (MIT):Licensing
+
+The MIT License (MIT)
+Copyright (c) 2013 Ralph Caraveo (deckarep@gmail.com)
+
+Permission is hereby granted, free of charge, to any person obtaining a copy of
+this software and associated documentation files (the "Software"), to deal in
+the Software without restriction, including without limitation the rights to
+use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies
+of the Software, and to permit persons to whom the Software is furnished to do
+so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package mapset
+
+// AddAll, RemoveAll, RetainAll and SymmetricDifferenceUpdate mutate the
+// receiver in place instead of allocating a new set.
+
+// AddAll adds every element of other to set, in place.
+func (set *threadUnsafeSet) AddAll(other Set) {
+	for elem := range other.Iter() {
+		(*set)[elem] = struct{}{}
+	}
+}
+
+// RemoveAll removes every element of other from set, in place. This is the
+// in-place equivalent of set = set.Difference(other).
+func (set *threadUnsafeSet) RemoveAll(other Set) {
+	for elem := range other.Iter() {
+		delete(*set, elem)
+	}
+}
+
+// RetainAll removes every element of set that is not also in other, in
+// place. This is the in-place equivalent of set = set.Intersect(other).
+func (set *threadUnsafeSet) RetainAll(other Set) {
+	for elem := range *set {
+		if !other.Contains(elem) {
+			delete(*set, elem)
+		}
+	}
+}
+
+// SymmetricDifferenceUpdate replaces set's contents with the symmetric
+// difference of set and other, in place.
+func (set *threadUnsafeSet) SymmetricDifferenceUpdate(other Set) {
+	for elem := range other.Iter() {
+		if _, found := (*set)[elem]; found {
+			delete(*set, elem)
+		} else {
+			(*set)[elem] = struct{}{}
+		}
+	}
+}
+
+// snapshotUnsafe copies other's elements into a freestanding threadUnsafeSet
+// before set's lock is taken, so AddAll/RemoveAll/RetainAll/
+// SymmetricDifferenceUpdate never hold set.l and reach back into other at
+// the same time. Without this, two threadSafeSets mutating each other
+// concurrently (a.AddAll(b) racing b.AddAll(a)) would each hold their own
+// write lock and block trying to read the other's, an AB-BA deadlock.
+func snapshotUnsafe(other Set) *threadUnsafeSet {
+	snapshot := newThreadUnsafeSet()
+	for elem := range other.Iter() {
+		snapshot.Add(elem)
+	}
+	return &snapshot
+}
+
+// AddAll adds every element of other to set, in place.
+func (set *threadSafeSet) AddAll(other Set) {
+	if o, ok := other.(*threadSafeSet); ok && o == set {
+		return
+	}
+	snapshot := snapshotUnsafe(other)
+
+	set.l.Lock()
+	defer set.l.Unlock()
+	set.S.AddAll(snapshot)
+}
+
+// RemoveAll removes every element of other from set, in place. This is the
+// in-place equivalent of set = set.Difference(other).
+func (set *threadSafeSet) RemoveAll(other Set) {
+	if o, ok := other.(*threadSafeSet); ok && o == set {
+		set.Clear()
+		return
+	}
+	snapshot := snapshotUnsafe(other)
+
+	set.l.Lock()
+	defer set.l.Unlock()
+	set.S.RemoveAll(snapshot)
+}
+
+// RetainAll removes every element of set that is not also in other, in
+// place. This is the in-place equivalent of set = set.Intersect(other).
+func (set *threadSafeSet) RetainAll(other Set) {
+	if o, ok := other.(*threadSafeSet); ok && o == set {
+		return
+	}
+	snapshot := snapshotUnsafe(other)
+
+	set.l.Lock()
+	defer set.l.Unlock()
+	set.S.RetainAll(snapshot)
+}
+
+// SymmetricDifferenceUpdate replaces set's contents with the symmetric
+// difference of set and other, in place.
+func (set *threadSafeSet) SymmetricDifferenceUpdate(other Set) {
+	if o, ok := other.(*threadSafeSet); ok && o == set {
+		set.Clear()
+		return
+	}
+	snapshot := snapshotUnsafe(other)
+
+	set.l.Lock()
+	defer set.l.Unlock()
+	set.S.SymmetricDifferenceUpdate(snapshot)
+}
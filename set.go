@@ -0,0 +1,82 @@
+/*
+Open Source Initiative OSI - The MIT License (MIT):Licensing
+
+The MIT License (MIT)
+Copyright (c) 2013 Ralph Caraveo (deckarep@gmail.com)
+
+Permission is hereby granted, free of charge, to any person obtaining a copy of
+this software and associated documentation files (the "Software"), to deal in
+the Software without restriction, including without limitation the rights to
+use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies
+of the Software, and to permit persons to whom the Software is furnished to do
+so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package mapset
+
+// Set is the primary interface implemented by threadSafeSet, threadUnsafeSet
+// and shardedSet.
+type Set interface {
+	Add(i interface{}) bool
+	Contains(i ...interface{}) bool
+	IsSubset(other Set) bool
+	IsProperSubset(other Set) bool
+	IsSuperset(other Set) bool
+	IsProperSuperset(other Set) bool
+	Union(other Set) Set
+	Intersect(other Set) Set
+	Difference(other Set) Set
+	SymmetricDifference(other Set) Set
+	Clear()
+	Remove(i interface{})
+	Cardinality() int
+	Each(cb func(interface{}) bool)
+	Iter() <-chan interface{}
+	Iterator() *Iterator
+	Equal(other Set) bool
+	Clone() Set
+	String() string
+	PowerSet() Set
+	Pop() interface{}
+	CartesianProduct(other Set) Set
+	ToSlice() []interface{}
+	MarshalJSON() ([]byte, error)
+	UnmarshalJSON(b []byte) error
+	Filter(pred func(interface{}) bool) Set
+	Map(fn func(interface{}) interface{}) Set
+	Reduce(init interface{}, fn func(acc, elem interface{}) interface{}) interface{}
+}
+
+// Iterator is returned by Iterator() on the various Set implementations. C
+// is the channel elements are delivered on; call Stop to release it early.
+type Iterator struct {
+	C    <-chan interface{}
+	stop chan struct{}
+}
+
+// Stop terminates the Iterator, no further values will be sent.
+func (i *Iterator) Stop() {
+	close(i.stop)
+	for range i.C {
+	}
+}
+
+func newIterator() (*Iterator, chan<- interface{}, <-chan struct{}) {
+	itr := &Iterator{
+		stop: make(chan struct{}),
+	}
+	ch := make(chan interface{})
+	itr.C = ch
+	return itr, ch, itr.stop
+}
@@ -0,0 +1,384 @@
+/*
+Open Source Initiative OSI - The MIT License (MIT):Licensing
+
+The MIT License (MIT)
+Copyright (c) 2013 Ralph Caraveo (deckarep@gmail.com)
+
+Permission is hereby granted, free of charge, to any person obtaining a copy of
+this software and associated documentation files (the "Software"), to deal in
+the Software without restriction, including without limitation the rights to
+use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies
+of the Software, and to permit persons to whom the Software is furnished to do
+so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package mapset
+
+import (
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"sync"
+)
+
+// shard is one independently-locked partition of a shardedSet.
+type shard struct {
+	l sync.RWMutex
+	m map[interface{}]struct{}
+}
+
+// shardedSet partitions its elements across a fixed number of independently
+// locked shards instead of guarding a single map with one sync.RWMutex, so
+// Add/Contains/Remove only ever contend with goroutines hashing to the same
+// shard.
+type shardedSet struct {
+	shards []*shard
+}
+
+// NewShardedSet creates a new Set backed by the given number of shards.
+// shards must be at least 1; values below 1 are treated as 1.
+func NewShardedSet(shards int) Set {
+	if shards < 1 {
+		shards = 1
+	}
+	s := &shardedSet{shards: make([]*shard, shards)}
+	for i := range s.shards {
+		s.shards[i] = &shard{m: make(map[interface{}]struct{})}
+	}
+	return s
+}
+
+func shardHash(i interface{}) uint32 {
+	h := fnv.New32a()
+	fmt.Fprintf(h, "%v", i)
+	return h.Sum32()
+}
+
+func (set *shardedSet) shardFor(i interface{}) *shard {
+	return set.shards[shardHash(i)%uint32(len(set.shards))]
+}
+
+func (set *shardedSet) Add(i interface{}) bool {
+	sh := set.shardFor(i)
+	sh.l.Lock()
+	defer sh.l.Unlock()
+
+	if _, found := sh.m[i]; found {
+		return false
+	}
+	sh.m[i] = struct{}{}
+	return true
+}
+
+func (set *shardedSet) Contains(i ...interface{}) bool {
+	for _, elem := range i {
+		sh := set.shardFor(elem)
+		sh.l.RLock()
+		_, found := sh.m[elem]
+		sh.l.RUnlock()
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+func (set *shardedSet) Remove(i interface{}) {
+	sh := set.shardFor(i)
+	sh.l.Lock()
+	delete(sh.m, i)
+	sh.l.Unlock()
+}
+
+func (set *shardedSet) Cardinality() int {
+	total := 0
+	for _, sh := range set.shards {
+		sh.l.RLock()
+		total += len(sh.m)
+		sh.l.RUnlock()
+	}
+	return total
+}
+
+func (set *shardedSet) Clear() {
+	for _, sh := range set.shards {
+		sh.l.Lock()
+		sh.m = make(map[interface{}]struct{})
+		sh.l.Unlock()
+	}
+}
+
+func (set *shardedSet) Each(cb func(interface{}) bool) {
+	for _, sh := range set.shards {
+		sh.l.RLock()
+		stop := false
+		for elem := range sh.m {
+			if cb(elem) {
+				stop = true
+				break
+			}
+		}
+		sh.l.RUnlock()
+		if stop {
+			break
+		}
+	}
+}
+
+func (set *shardedSet) Iter() <-chan interface{} {
+	ch := make(chan interface{})
+	go func() {
+		set.Each(func(elem interface{}) bool {
+			ch <- elem
+			return false
+		})
+		close(ch)
+	}()
+	return ch
+}
+
+func (set *shardedSet) Iterator() *Iterator {
+	iterator, ch, stopCh := newIterator()
+	go func() {
+	L:
+		for _, sh := range set.shards {
+			sh.l.RLock()
+			for elem := range sh.m {
+				select {
+				case <-stopCh:
+					sh.l.RUnlock()
+					break L
+				case ch <- elem:
+				}
+			}
+			sh.l.RUnlock()
+		}
+		close(ch)
+	}()
+	return iterator
+}
+
+func (set *shardedSet) ToSlice() []interface{} {
+	keys := make([]interface{}, 0, set.Cardinality())
+	set.Each(func(elem interface{}) bool {
+		keys = append(keys, elem)
+		return false
+	})
+	return keys
+}
+
+func (set *shardedSet) String() string {
+	return fmt.Sprintf("%v", set.ToSlice())
+}
+
+func (set *shardedSet) Clone() Set {
+	clone := NewShardedSet(len(set.shards))
+	set.Each(func(elem interface{}) bool {
+		clone.Add(elem)
+		return false
+	})
+	return clone
+}
+
+func (set *shardedSet) IsSubset(other Set) bool {
+	if set.Cardinality() > other.Cardinality() {
+		return false
+	}
+	isSubset := true
+	set.Each(func(elem interface{}) bool {
+		if !other.Contains(elem) {
+			isSubset = false
+			return true
+		}
+		return false
+	})
+	return isSubset
+}
+
+func (set *shardedSet) IsProperSubset(other Set) bool {
+	return set.IsSubset(other) && set.Cardinality() < other.Cardinality()
+}
+
+func (set *shardedSet) IsSuperset(other Set) bool {
+	return other.IsSubset(set)
+}
+
+func (set *shardedSet) IsProperSuperset(other Set) bool {
+	return other.IsProperSubset(set)
+}
+
+// union checks each shard's elements against other concurrently, one
+// goroutine per shard, adding those selected by keepBoth and keepSelfOnly to
+// the result, and waits for all shards to finish. If keepOtherOnly is set,
+// it then adds the elements of other that are not in set, in one sequential
+// pass over other.
+func (set *shardedSet) union(other Set, keepBoth, keepSelfOnly, keepOtherOnly bool) Set {
+	result := NewShardedSet(len(set.shards))
+
+	var wg sync.WaitGroup
+	wg.Add(len(set.shards))
+	for _, sh := range set.shards {
+		go func(sh *shard) {
+			defer wg.Done()
+			sh.l.RLock()
+			for elem := range sh.m {
+				in := other.Contains(elem)
+				if (in && keepBoth) || (!in && keepSelfOnly) {
+					result.Add(elem)
+				}
+			}
+			sh.l.RUnlock()
+		}(sh)
+	}
+	wg.Wait()
+
+	if keepOtherOnly {
+		other.Each(func(elem interface{}) bool {
+			if !set.Contains(elem) {
+				result.Add(elem)
+			}
+			return false
+		})
+	}
+
+	return result
+}
+
+func (set *shardedSet) Union(other Set) Set {
+	return set.union(other, true, true, true)
+}
+
+func (set *shardedSet) Intersect(other Set) Set {
+	return set.union(other, true, false, false)
+}
+
+func (set *shardedSet) Difference(other Set) Set {
+	return set.union(other, false, true, false)
+}
+
+func (set *shardedSet) SymmetricDifference(other Set) Set {
+	return set.union(other, false, true, true)
+}
+
+func (set *shardedSet) Equal(other Set) bool {
+	if set.Cardinality() != other.Cardinality() {
+		return false
+	}
+	return set.IsSubset(other)
+}
+
+func (set *shardedSet) PowerSet() Set {
+	nullset := NewShardedSet(len(set.shards))
+	powSet := NewShardedSet(len(set.shards))
+	powSet.Add(nullset)
+
+	set.Each(func(es interface{}) bool {
+		u := NewShardedSet(len(set.shards))
+		for subset := range powSet.Iter() {
+			p := NewShardedSet(len(set.shards))
+			sub := subset.(Set)
+			sub.Each(func(k interface{}) bool {
+				p.Add(k)
+				return false
+			})
+			p.Add(es)
+			u.Add(p)
+		}
+		for elem := range u.Iter() {
+			powSet.Add(elem)
+		}
+		return false
+	})
+
+	return powSet
+}
+
+func (set *shardedSet) Pop() interface{} {
+	for _, sh := range set.shards {
+		sh.l.Lock()
+		for elem := range sh.m {
+			delete(sh.m, elem)
+			sh.l.Unlock()
+			return elem
+		}
+		sh.l.Unlock()
+	}
+	return nil
+}
+
+func (set *shardedSet) CartesianProduct(other Set) Set {
+	product := NewShardedSet(len(set.shards))
+	set.Each(func(a interface{}) bool {
+		other.Each(func(b interface{}) bool {
+			product.Add(orderedPair{first: a, second: b})
+			return false
+		})
+		return false
+	})
+	return product
+}
+
+// orderedPair is the element type produced by shardedSet.CartesianProduct.
+type orderedPair struct {
+	first  interface{}
+	second interface{}
+}
+
+func (pair orderedPair) String() string {
+	return fmt.Sprintf("(%v, %v)", pair.first, pair.second)
+}
+
+func (set *shardedSet) MarshalJSON() ([]byte, error) {
+	return json.Marshal(set.ToSlice())
+}
+
+func (set *shardedSet) UnmarshalJSON(p []byte) error {
+	var items []interface{}
+	if err := json.Unmarshal(p, &items); err != nil {
+		return err
+	}
+	for _, item := range items {
+		set.Add(item)
+	}
+	return nil
+}
+
+func (set *shardedSet) Filter(pred func(interface{}) bool) Set {
+	result := NewShardedSet(len(set.shards))
+	set.Each(func(elem interface{}) bool {
+		if pred(elem) {
+			result.Add(elem)
+		}
+		return false
+	})
+	return result
+}
+
+func (set *shardedSet) Map(fn func(interface{}) interface{}) Set {
+	result := NewShardedSet(len(set.shards))
+	set.Each(func(elem interface{}) bool {
+		result.Add(fn(elem))
+		return false
+	})
+	return result
+}
+
+func (set *shardedSet) Reduce(init interface{}, fn func(acc, elem interface{}) interface{}) interface{} {
+	acc := init
+	set.Each(func(elem interface{}) bool {
+		acc = fn(acc, elem)
+		return false
+	})
+	return acc
+}
@@ -0,0 +1,168 @@
+/*
+Open Source Initiative OSI - The MIT License (MIT):Licensing
+
+The MIT License (MIT)
+Copyright (c) 2013 Ralph Caraveo (deckarep@gmail.com)
+
+Permission is hereby granted, free of charge, to any person obtaining a copy of
+this software and associated documentation files (the "Software"), to deal in
+the Software without restriction, including without limitation the rights to
+use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies
+of the Software, and to permit persons to whom the Software is furnished to do
+so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package mapset
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+)
+
+func fillShardedSet(shards int, elems ...interface{}) Set {
+	s := NewShardedSet(shards)
+	for _, e := range elems {
+		s.Add(e)
+	}
+	return s
+}
+
+func TestShardedSetUnion(t *testing.T) {
+	for _, shards := range []int{1, 4, 16} {
+		a := fillShardedSet(shards, 1, 2, 3)
+		b := fillShardedSet(shards, 3, 4, 5)
+
+		union := a.Union(b)
+		if union.Cardinality() != 5 {
+			t.Fatalf("shards=%d: Union cardinality = %d, want 5", shards, union.Cardinality())
+		}
+		for _, elem := range []interface{}{1, 2, 3, 4, 5} {
+			if !union.Contains(elem) {
+				t.Errorf("shards=%d: Union missing element %v", shards, elem)
+			}
+		}
+	}
+}
+
+func TestShardedSetIntersect(t *testing.T) {
+	for _, shards := range []int{1, 4, 16} {
+		a := fillShardedSet(shards, 1, 2, 3)
+		b := fillShardedSet(shards, 2, 3, 4)
+
+		intersect := a.Intersect(b)
+		if intersect.Cardinality() != 2 {
+			t.Fatalf("shards=%d: Intersect cardinality = %d, want 2", shards, intersect.Cardinality())
+		}
+		if !intersect.Contains(2) || !intersect.Contains(3) {
+			t.Errorf("shards=%d: Intersect = %v, want {2, 3}", shards, intersect)
+		}
+	}
+}
+
+func TestShardedSetDifference(t *testing.T) {
+	for _, shards := range []int{1, 4, 16} {
+		a := fillShardedSet(shards, 1, 2, 3)
+		b := fillShardedSet(shards, 2, 3, 4)
+
+		diff := a.Difference(b)
+		if diff.Cardinality() != 1 || !diff.Contains(1) {
+			t.Errorf("shards=%d: Difference = %v, want {1}", shards, diff)
+		}
+	}
+}
+
+func TestShardedSetSymmetricDifference(t *testing.T) {
+	for _, shards := range []int{1, 4, 16} {
+		a := fillShardedSet(shards, 1, 2, 3)
+		b := fillShardedSet(shards, 2, 3, 4)
+
+		symDiff := a.SymmetricDifference(b)
+		if symDiff.Cardinality() != 2 || !symDiff.Contains(1) || !symDiff.Contains(4) {
+			t.Errorf("shards=%d: SymmetricDifference = %v, want {1, 4}", shards, symDiff)
+		}
+	}
+}
+
+func TestShardedSetPowerSet(t *testing.T) {
+	for _, shards := range []int{1, 4} {
+		s := fillShardedSet(shards, 1, 2)
+
+		powSet := s.PowerSet()
+		if powSet.Cardinality() != 4 {
+			t.Fatalf("shards=%d: PowerSet cardinality = %d, want 4", shards, powSet.Cardinality())
+		}
+
+		found := map[string]bool{}
+		for subset := range powSet.Iter() {
+			found[subset.(Set).String()] = true
+		}
+		if len(found) != 4 {
+			t.Errorf("shards=%d: PowerSet produced %d distinct subsets, want 4", shards, len(found))
+		}
+	}
+}
+
+func TestShardedSetCartesianProduct(t *testing.T) {
+	for _, shards := range []int{1, 4} {
+		a := fillShardedSet(shards, 1, 2)
+		b := fillShardedSet(shards, "x", "y")
+
+		product := a.CartesianProduct(b)
+		if product.Cardinality() != 4 {
+			t.Fatalf("shards=%d: CartesianProduct cardinality = %d, want 4", shards, product.Cardinality())
+		}
+		for _, pair := range []orderedPair{{1, "x"}, {1, "y"}, {2, "x"}, {2, "y"}} {
+			if !product.Contains(pair) {
+				t.Errorf("shards=%d: CartesianProduct missing pair %v", shards, pair)
+			}
+		}
+	}
+}
+
+// BenchmarkShardedSetAddContendedSingleShard pins shards=1, so every
+// goroutine serializes on the same RWMutex, the same way a plain
+// threadSafeSet does.
+func BenchmarkShardedSetAddContendedSingleShard(b *testing.B) {
+	benchmarkShardedSetAddContended(b, 1)
+}
+
+func BenchmarkShardedSetAddContended16Shards(b *testing.B) {
+	benchmarkShardedSetAddContended(b, 16)
+}
+
+func BenchmarkShardedSetAddContended64Shards(b *testing.B) {
+	benchmarkShardedSetAddContended(b, 64)
+}
+
+func benchmarkShardedSetAddContended(b *testing.B, shards int) {
+	s := NewShardedSet(shards)
+	const goroutines = 32
+
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		var wg sync.WaitGroup
+		wg.Add(goroutines)
+		for g := 0; g < goroutines; g++ {
+			go func(g int) {
+				defer wg.Done()
+				for i := 0; i < 100; i++ {
+					s.Add(fmt.Sprintf("g%d-%d", g, i))
+					s.Contains(fmt.Sprintf("g%d-%d", g, i))
+				}
+			}(g)
+		}
+		wg.Wait()
+	}
+}
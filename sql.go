@@ -0,0 +1,158 @@
+/*
+Open Source Initiative OSI - The MIT License (MIT):Licensing
+
+The MIT License (MIT)
+Copyright (c) 2013 Ralph Caraveo (deckarep@gmail.com)
+
+Permission is hereby granted, free of charge, to any person obtaining a copy of
+this software and associated documentation files (the "Software"), to deal in
+the Software without restriction, including without limitation the rights to
+use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies
+of the Software, and to permit persons to whom the Software is furnished to do
+so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package mapset
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// SQLEncoding selects how a Set is encoded when it is written to, or read
+// from, a database column via the driver.Valuer/sql.Scanner implementations
+// below. It defaults to SQLEncodingJSON.
+type SQLEncoding int
+
+const (
+	// SQLEncodingJSON stores the set as a JSON array, e.g. ["a","b","c"].
+	SQLEncodingJSON SQLEncoding = iota
+	// SQLEncodingCSV stores the set as a comma-delimited string, e.g. "a,b,c".
+	// Elements are formatted with fmt.Sprintf("%v", elem), so it is only
+	// lossless for element types that round-trip through their string form
+	// (e.g. strings that contain no comma, and integer types when the column
+	// is scanned back as text and parsed by the caller). Scan splits on every
+	// comma, so an element whose string form contains one is read back as
+	// several elements.
+	SQLEncodingCSV
+)
+
+// DefaultSQLEncoding is the package-level SQLEncoding used by Value and
+// GormDataType for every Set unless documented otherwise. Tests and callers
+// that need a different on-the-wire representation can change it before
+// persisting or reading any Set-valued column.
+var DefaultSQLEncoding = SQLEncodingJSON
+
+func sqlValue(s Set) (driver.Value, error) {
+	switch DefaultSQLEncoding {
+	case SQLEncodingCSV:
+		parts := make([]string, 0, s.Cardinality())
+		for elem := range s.Iter() {
+			parts = append(parts, fmt.Sprintf("%v", elem))
+		}
+		return strings.Join(parts, ","), nil
+	default:
+		b, err := s.MarshalJSON()
+		if err != nil {
+			return nil, err
+		}
+		return string(b), nil
+	}
+}
+
+func sqlScan(s Set, src interface{}) error {
+	var raw string
+	switch v := src.(type) {
+	case nil:
+		return nil
+	case string:
+		raw = v
+	case []byte:
+		raw = string(v)
+	default:
+		return fmt.Errorf("mapset: unsupported Scan source type %T", src)
+	}
+
+	switch DefaultSQLEncoding {
+	case SQLEncodingCSV:
+		if raw == "" {
+			return nil
+		}
+		for _, part := range strings.Split(raw, ",") {
+			s.Add(part)
+		}
+		return nil
+	default:
+		if raw == "" {
+			return nil
+		}
+		var items []interface{}
+		if err := json.Unmarshal([]byte(raw), &items); err != nil {
+			return err
+		}
+		for _, item := range items {
+			s.Add(item)
+		}
+		return nil
+	}
+}
+
+// Value implements driver.Valuer so a threadSafeSet can be written directly
+// to a database column, encoded per DefaultSQLEncoding.
+func (set *threadSafeSet) Value() (driver.Value, error) {
+	set.l.RLock()
+	defer set.l.RUnlock()
+	return sqlValue(&set.S)
+}
+
+// Scan implements sql.Scanner, decoding a database column (TEXT or JSON)
+// produced by Value back into the set. The set is populated in place rather
+// than replaced, matching the behavior of UnmarshalJSON.
+func (set *threadSafeSet) Scan(src interface{}) error {
+	set.l.Lock()
+	defer set.l.Unlock()
+	return sqlScan(&set.S, src)
+}
+
+// GormDataType tells GORM v2 which column type to use for a Set-valued
+// struct field.
+func (set *threadSafeSet) GormDataType() string {
+	if DefaultSQLEncoding == SQLEncodingCSV {
+		return "text"
+	}
+	return "json"
+}
+
+// Value implements driver.Valuer so a threadUnsafeSet can be written
+// directly to a database column, encoded per DefaultSQLEncoding.
+func (set *threadUnsafeSet) Value() (driver.Value, error) {
+	return sqlValue(set)
+}
+
+// Scan implements sql.Scanner, decoding a database column (TEXT or JSON)
+// produced by Value back into the set.
+func (set *threadUnsafeSet) Scan(src interface{}) error {
+	return sqlScan(set, src)
+}
+
+// GormDataType tells GORM v2 which column type to use for a Set-valued
+// struct field.
+func (set *threadUnsafeSet) GormDataType() string {
+	if DefaultSQLEncoding == SQLEncodingCSV {
+		return "text"
+	}
+	return "json"
+}
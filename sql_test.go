@@ -0,0 +1,54 @@
+/*
+Open Source Initiative OSI - The MIT License (MIT):Licensing
+
+The MIT License (MIT)
+Copyright (c) 2013 Ralph Caraveo (deckarep@gmail.com)
+
+Permission is hereby granted, free of charge, to any person obtaining a copy of
+this software and associated documentation files (the "Software"), to deal in
+the Software without restriction, including without limitation the rights to
+use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies
+of the Software, and to permit persons to whom the Software is furnished to do
+so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package mapset
+
+import "testing"
+
+// TestThreadSafeSetSQLRoundTrip guards against Value/Scan re-entering the
+// public locking API (set.Add, set.Iter, set.MarshalJSON) from within
+// set.l's own critical section. Scan holds the write lock, so calling
+// set.Add from it deadlocks every time; a nested read lock in Value blocks
+// whenever a writer is waiting.
+func TestThreadSafeSetSQLRoundTrip(t *testing.T) {
+	orig := &threadSafeSet{S: newThreadUnsafeSet()}
+	orig.Add("a")
+	orig.Add("b")
+	orig.Add("c")
+
+	val, err := orig.Value()
+	if err != nil {
+		t.Fatalf("Value() error = %v", err)
+	}
+
+	got := &threadSafeSet{S: newThreadUnsafeSet()}
+	if err := got.Scan(val); err != nil {
+		t.Fatalf("Scan() error = %v", err)
+	}
+
+	if got.Cardinality() != 3 || !got.Contains("a", "b", "c") {
+		t.Errorf("Scan(Value()) = %v, want {a, b, c}", got)
+	}
+}
@@ -196,6 +196,53 @@ func (set *threadSafeSet) Iterator() *Iterator {
 	return iterator
 }
 
+// SnapshotIter returns a channel fed from a point-in-time copy of the set's
+// elements rather than the live map. Unlike Iter, which holds l.RLock for
+// the entire lifetime of the feeding goroutine, SnapshotIter takes the
+// RLock just long enough to copy element references into a slice, then
+// releases it before sending anything on the channel. The tradeoff is that
+// the iteration can run arbitrarily long, or be abandoned entirely, without
+// blocking writers, at the cost of holding a full copy of the set in memory
+// and not reflecting concurrent Add/Remove calls made after the snapshot
+// was taken. Callers that need strong "no writes during traversal"
+// semantics should keep using Iter or Iterator instead.
+func (set *threadSafeSet) SnapshotIter() <-chan interface{} {
+	snapshot := set.ToSlice()
+
+	ch := make(chan interface{})
+	go func() {
+		for _, elem := range snapshot {
+			ch <- elem
+		}
+		close(ch)
+	}()
+
+	return ch
+}
+
+// SnapshotIterator is the Iterator counterpart of SnapshotIter: it hands
+// back an Iterator fed from a snapshot of the set's elements taken under a
+// brief RLock, so a slow consumer, or one that forgets to call Stop, can
+// never block a writer. See SnapshotIter for the full memory tradeoff.
+func (set *threadSafeSet) SnapshotIterator() *Iterator {
+	snapshot := set.ToSlice()
+	iterator, ch, stopCh := newIterator()
+
+	go func() {
+	L:
+		for _, elem := range snapshot {
+			select {
+			case <-stopCh:
+				break L
+			case ch <- elem:
+			}
+		}
+		close(ch)
+	}()
+
+	return iterator
+}
+
 func (set *threadSafeSet) Equal(other Set) bool {
 	o := other.(*threadSafeSet)
 
@@ -0,0 +1,287 @@
+/*
+Open Source Initiative OSI - The MIT License (MIT):Licensing
+
+The MIT License (MIT)
+Copyright (c) 2013 Ralph Caraveo (deckarep@gmail.com)
+
+Permission is hereby granted, free of charge, to any person obtaining a copy of
+this software and associated documentation files (the "Software"), to deal in
+the Software without restriction, including without limitation the rights to
+use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies
+of the Software, and to permit persons to whom the Software is furnished to do
+so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package mapset
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+type threadUnsafeSet map[interface{}]struct{}
+
+// NewThreadUnsafeSet creates and returns a new unsafe Set, without the
+// overhead of synchronization.
+func NewThreadUnsafeSet(s ...interface{}) Set {
+	set := newThreadUnsafeSet()
+	for _, item := range s {
+		set.Add(item)
+	}
+	return &set
+}
+
+func newThreadUnsafeSet() threadUnsafeSet {
+	return make(threadUnsafeSet)
+}
+
+func (set *threadUnsafeSet) Add(i interface{}) bool {
+	_, found := (*set)[i]
+	if found {
+		return false
+	}
+	(*set)[i] = struct{}{}
+	return true
+}
+
+func (set *threadUnsafeSet) Contains(i ...interface{}) bool {
+	for _, val := range i {
+		if _, ok := (*set)[val]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+func (set *threadUnsafeSet) IsSubset(other Set) bool {
+	o := other.(*threadUnsafeSet)
+	if set.Cardinality() > o.Cardinality() {
+		return false
+	}
+	for elem := range *set {
+		if !o.Contains(elem) {
+			return false
+		}
+	}
+	return true
+}
+
+func (set *threadUnsafeSet) IsProperSubset(other Set) bool {
+	return set.IsSubset(other) && set.Cardinality() < other.Cardinality()
+}
+
+func (set *threadUnsafeSet) IsSuperset(other Set) bool {
+	return other.IsSubset(set)
+}
+
+func (set *threadUnsafeSet) IsProperSuperset(other Set) bool {
+	return other.IsProperSubset(set)
+}
+
+func (set *threadUnsafeSet) Union(other Set) Set {
+	o := other.(*threadUnsafeSet)
+
+	unionedSet := newThreadUnsafeSet()
+	for elem := range *set {
+		unionedSet.Add(elem)
+	}
+	for elem := range *o {
+		unionedSet.Add(elem)
+	}
+	return &unionedSet
+}
+
+func (set *threadUnsafeSet) Intersect(other Set) Set {
+	o := other.(*threadUnsafeSet)
+
+	intersection := newThreadUnsafeSet()
+	smaller, larger := set, o
+	if o.Cardinality() < set.Cardinality() {
+		smaller, larger = o, set
+	}
+	for elem := range *smaller {
+		if larger.Contains(elem) {
+			intersection.Add(elem)
+		}
+	}
+	return &intersection
+}
+
+func (set *threadUnsafeSet) Difference(other Set) Set {
+	o := other.(*threadUnsafeSet)
+
+	diff := newThreadUnsafeSet()
+	for elem := range *set {
+		if !o.Contains(elem) {
+			diff.Add(elem)
+		}
+	}
+	return &diff
+}
+
+func (set *threadUnsafeSet) SymmetricDifference(other Set) Set {
+	o := other.(*threadUnsafeSet)
+
+	aDiff := set.Difference(o).(*threadUnsafeSet)
+	bDiff := o.Difference(set).(*threadUnsafeSet)
+	return aDiff.Union(bDiff)
+}
+
+func (set *threadUnsafeSet) Clear() {
+	*set = newThreadUnsafeSet()
+}
+
+func (set *threadUnsafeSet) Remove(i interface{}) {
+	delete(*set, i)
+}
+
+func (set *threadUnsafeSet) Cardinality() int {
+	return len(*set)
+}
+
+func (set *threadUnsafeSet) Each(cb func(interface{}) bool) {
+	for elem := range *set {
+		if cb(elem) {
+			break
+		}
+	}
+}
+
+func (set *threadUnsafeSet) Iter() <-chan interface{} {
+	ch := make(chan interface{})
+	go func() {
+		for elem := range *set {
+			ch <- elem
+		}
+		close(ch)
+	}()
+	return ch
+}
+
+func (set *threadUnsafeSet) Iterator() *Iterator {
+	iterator, ch, stopCh := newIterator()
+
+	go func() {
+	L:
+		for elem := range *set {
+			select {
+			case <-stopCh:
+				break L
+			case ch <- elem:
+			}
+		}
+		close(ch)
+	}()
+
+	return iterator
+}
+
+func (set *threadUnsafeSet) Equal(other Set) bool {
+	o := other.(*threadUnsafeSet)
+
+	if set.Cardinality() != o.Cardinality() {
+		return false
+	}
+	for elem := range *set {
+		if !o.Contains(elem) {
+			return false
+		}
+	}
+	return true
+}
+
+func (set *threadUnsafeSet) Clone() Set {
+	clone := newThreadUnsafeSet()
+	for elem := range *set {
+		clone.Add(elem)
+	}
+	return &clone
+}
+
+func (set *threadUnsafeSet) String() string {
+	items := make([]string, 0, len(*set))
+	for elem := range *set {
+		items = append(items, fmt.Sprintf("%v", elem))
+	}
+	return fmt.Sprintf("Set{%s}", strings.Join(items, ", "))
+}
+
+func (set *threadUnsafeSet) PowerSet() Set {
+	powSet := newThreadUnsafeSet()
+	nullset := newThreadUnsafeSet()
+	powSet.Add(&nullset)
+
+	for es := range *set {
+		u := newThreadUnsafeSet()
+		for er := range powSet {
+			p := newThreadUnsafeSet()
+			if sub, ok := er.(*threadUnsafeSet); ok {
+				for k := range *sub {
+					p.Add(k)
+				}
+			}
+			p.Add(es)
+			u.Add(&p)
+		}
+		for elem := range u {
+			powSet.Add(elem)
+		}
+	}
+
+	return &powSet
+}
+
+func (set *threadUnsafeSet) Pop() interface{} {
+	for elem := range *set {
+		delete(*set, elem)
+		return elem
+	}
+	return nil
+}
+
+func (set *threadUnsafeSet) CartesianProduct(other Set) Set {
+	o := other.(*threadUnsafeSet)
+
+	cartProduct := newThreadUnsafeSet()
+	for elem := range *set {
+		for otherElem := range *o {
+			cartProduct.Add(orderedPair{first: elem, second: otherElem})
+		}
+	}
+	return &cartProduct
+}
+
+func (set *threadUnsafeSet) ToSlice() []interface{} {
+	keys := make([]interface{}, 0, set.Cardinality())
+	for elem := range *set {
+		keys = append(keys, elem)
+	}
+	return keys
+}
+
+func (set *threadUnsafeSet) MarshalJSON() ([]byte, error) {
+	return json.Marshal(set.ToSlice())
+}
+
+func (set *threadUnsafeSet) UnmarshalJSON(b []byte) error {
+	var items []interface{}
+	if err := json.Unmarshal(b, &items); err != nil {
+		return err
+	}
+	for _, item := range items {
+		set.Add(item)
+	}
+	return nil
+}